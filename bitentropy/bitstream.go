@@ -0,0 +1,67 @@
+// Package bitentropy computes Shannon, min-, and collision entropy over
+// arbitrary-width symbols drawn from a byte stream, either as a raw bit
+// stream (1-16 bits per symbol) or as byte n-grams (1-4 bytes per symbol).
+package bitentropy
+
+import "io"
+
+// BitStream reads a byte stream one bit at a time, in either MSB-first or
+// LSB-first order within each byte, and groups those bits into fixed-width
+// symbols.
+type BitStream struct {
+	r        io.ByteReader
+	msbFirst bool
+	cur      byte
+	bitPos   uint // bits already consumed from cur, 0..8
+	haveByte bool
+}
+
+// NewBitStream wraps r for bit-at-a-time reading. When msbFirst is true,
+// bits are consumed most-significant-bit first within each byte; otherwise
+// least-significant-bit first.
+func NewBitStream(r io.ByteReader, msbFirst bool) *BitStream {
+	return &BitStream{r: r, msbFirst: msbFirst}
+}
+
+func (b *BitStream) readBit() (byte, error) {
+	if !b.haveByte {
+		cur, err := b.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		b.cur = cur
+		b.bitPos = 0
+		b.haveByte = true
+	}
+
+	var bit byte
+	if b.msbFirst {
+		bit = (b.cur >> (7 - b.bitPos)) & 1
+	} else {
+		bit = (b.cur >> b.bitPos) & 1
+	}
+
+	b.bitPos++
+	if b.bitPos == 8 {
+		b.haveByte = false
+	}
+
+	return bit, nil
+}
+
+// Next returns the next width-bit symbol (1..32), most-significant bit
+// first in the returned value regardless of the stream's bit order. It
+// returns an error, discarding any bits already consumed for this symbol,
+// once the underlying reader runs out before width bits have been read.
+func (b *BitStream) Next(width int) (uint32, error) {
+	var symbol uint32
+	for i := 0; i < width; i++ {
+		bit, err := b.readBit()
+		if err != nil {
+			return 0, err
+		}
+		symbol = (symbol << 1) | uint32(bit)
+	}
+
+	return symbol, nil
+}
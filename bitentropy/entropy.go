@@ -0,0 +1,91 @@
+package bitentropy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+)
+
+// SymbolStats holds the three entropy measures computed over a symbol
+// histogram: Shannon entropy (average information content), min-entropy
+// (worst case, driven by the single most likely symbol), and collision
+// entropy (derived from the probability that two symbols match), all in
+// bits per symbol.
+type SymbolStats struct {
+	Shannon   float64
+	Min       float64
+	Collision float64
+}
+
+// SymbolEntropy reads r as a stream of width-bit symbols (1..16) in the
+// given bit order and returns the entropy of the resulting symbol
+// distribution. A trailing partial symbol at EOF is discarded.
+func SymbolEntropy(r io.Reader, width int, msbFirst bool) (SymbolStats, error) {
+	if width < 1 || width > 16 {
+		return SymbolStats{}, fmt.Errorf("symbol width must be between 1 and 16 bits, got %d", width)
+	}
+
+	bs := NewBitStream(bufio.NewReader(r), msbFirst)
+	counts := make(map[uint32]uint64)
+	var total uint64
+	for {
+		symbol, err := bs.Next(width)
+		if err != nil {
+			break
+		}
+		counts[symbol]++
+		total++
+	}
+
+	return statsFromCounts(counts, total), nil
+}
+
+// NGramEntropy reads r as a stream of non-overlapping k-byte groups (k=1..4)
+// and returns the entropy of the resulting symbol distribution. A trailing
+// partial group at EOF is discarded.
+func NGramEntropy(r io.Reader, k int) (SymbolStats, error) {
+	if k < 1 || k > 4 {
+		return SymbolStats{}, fmt.Errorf("n-gram size must be between 1 and 4 bytes, got %d", k)
+	}
+
+	br := bufio.NewReader(r)
+	counts := make(map[uint32]uint64)
+	var total uint64
+	buf := make([]byte, k)
+	for {
+		if _, err := io.ReadFull(br, buf); err != nil {
+			break
+		}
+		var symbol uint32
+		for _, b := range buf {
+			symbol = (symbol << 8) | uint32(b)
+		}
+		counts[symbol]++
+		total++
+	}
+
+	return statsFromCounts(counts, total), nil
+}
+
+func statsFromCounts(counts map[uint32]uint64, total uint64) SymbolStats {
+	if total == 0 {
+		return SymbolStats{}
+	}
+
+	var shannon, sumPSquared, maxP float64
+	for _, c := range counts {
+		p := float64(c) / float64(total)
+		shannon -= p * math.Log2(p)
+		sumPSquared += p * p
+		if p > maxP {
+			maxP = p
+		}
+	}
+
+	return SymbolStats{
+		Shannon:   shannon,
+		Min:       -math.Log2(maxP),
+		Collision: -math.Log2(sumPSquared),
+	}
+}
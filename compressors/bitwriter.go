@@ -0,0 +1,46 @@
+package compressors
+
+import "io"
+
+// bitWriter packs MSB-first bits into bytes and flushes whole bytes to the
+// underlying writer, zero-padding the final byte.
+type bitWriter struct {
+	w    io.Writer
+	cur  byte
+	nBit uint
+	n    int64
+}
+
+func newBitWriter(w io.Writer) *bitWriter {
+	return &bitWriter{w: w}
+}
+
+func (bw *bitWriter) writeBits(code uint32, length uint8) error {
+	for i := int(length) - 1; i >= 0; i-- {
+		bit := byte((code >> uint(i)) & 1)
+		bw.cur = bw.cur<<1 | bit
+		bw.nBit++
+		if bw.nBit == 8 {
+			if _, err := bw.w.Write([]byte{bw.cur}); err != nil {
+				return err
+			}
+			bw.n++
+			bw.cur, bw.nBit = 0, 0
+		}
+	}
+	return nil
+}
+
+// flush pads any partial final byte with zero bits and writes it, returning
+// the total number of bytes written.
+func (bw *bitWriter) flush() (int64, error) {
+	if bw.nBit > 0 {
+		bw.cur <<= 8 - bw.nBit
+		if _, err := bw.w.Write([]byte{bw.cur}); err != nil {
+			return bw.n, err
+		}
+		bw.n++
+		bw.cur, bw.nBit = 0, 0
+	}
+	return bw.n, nil
+}
@@ -0,0 +1,22 @@
+// Package compressors provides pluggable codecs for measuring how well real
+// compressors do against a file, as a counterpoint to the theoretical
+// entropy-derived lower bound.
+package compressors
+
+import "io"
+
+// Codec compresses the bytes read from r, writes the compressed form to w,
+// and reports the number of compressed bytes written.
+type Codec interface {
+	Name() string
+	Compress(r io.Reader, w io.Writer) (int64, error)
+}
+
+// All returns the standard set of codecs to run in `-compress` mode.
+func All() []Codec {
+	return []Codec{
+		GzipCodec{},
+		ZstdCodec{},
+		HuffmanCodec{},
+	}
+}
@@ -0,0 +1,37 @@
+package compressors
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// GzipCodec compresses via the standard library's DEFLATE-based gzip writer
+// at the default compression level.
+type GzipCodec struct{}
+
+func (GzipCodec) Name() string { return "gzip" }
+
+func (GzipCodec) Compress(r io.Reader, w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	gw := gzip.NewWriter(cw)
+	if _, err := io.Copy(gw, r); err != nil {
+		return 0, err
+	}
+	if err := gw.Close(); err != nil {
+		return 0, err
+	}
+	return cw.n, nil
+}
+
+// countingWriter tallies bytes written so codecs can report a compressed
+// size without requiring a seekable destination.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
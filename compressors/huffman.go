@@ -0,0 +1,142 @@
+package compressors
+
+import (
+	"io"
+	"math"
+	"sort"
+)
+
+// HuffmanCodec is a simple order-0 coder: each byte's code length is derived
+// directly from the file's own byte histogram (bit-length ~= -log2(p_i)),
+// length-limited and repaired into a valid prefix code, then assigned
+// canonical codewords. It exists to show the gap between a naive order-0
+// coder and modern LZ-based codecs like gzip and zstd.
+type HuffmanCodec struct{}
+
+func (HuffmanCodec) Name() string { return "huffman0" }
+
+const maxHuffmanLength = 16
+
+func (HuffmanCodec) Compress(r io.Reader, w io.Writer) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	var counts [256]uint32
+	for _, b := range data {
+		counts[b]++
+	}
+
+	lengths := huffmanLengths(counts, len(data))
+	codes := canonicalCodes(lengths)
+
+	bw := newBitWriter(w)
+	for _, b := range data {
+		c := codes[b]
+		if err := bw.writeBits(c.code, c.length); err != nil {
+			return 0, err
+		}
+	}
+
+	return bw.flush()
+}
+
+type huffCode struct {
+	code   uint32
+	length uint8
+}
+
+// huffmanLengths derives a per-symbol code length from -log2(p_i), clamps
+// it to [1, maxHuffmanLength], then repairs the result so the Kraft
+// inequality holds (sum of 2^-length_i over present symbols is <= 1), which
+// is necessary and sufficient for a valid prefix code to exist at those
+// lengths. This is a length-limiting repair, not a full package-merge
+// optimal construction, but it keeps the code both valid and close to the
+// entropy-derived ideal.
+func huffmanLengths(counts [256]uint32, total int) [256]uint8 {
+	var lengths [256]uint8
+	for symbol, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / float64(total)
+		l := int(math.Ceil(-math.Log2(p)))
+		if l < 1 {
+			l = 1
+		}
+		if l > maxHuffmanLength {
+			l = maxHuffmanLength
+		}
+		lengths[symbol] = uint8(l)
+	}
+
+	for kraftSum(lengths, counts) > 1.0 {
+		// Lengthening the shortest present code reduces the Kraft sum the
+		// fastest, so repeatedly bump whichever present symbol is cheapest.
+		shortest := -1
+		for symbol, c := range counts {
+			if c == 0 || lengths[symbol] >= maxHuffmanLength {
+				continue
+			}
+			if shortest == -1 || lengths[symbol] < lengths[shortest] {
+				shortest = symbol
+			}
+		}
+		if shortest == -1 {
+			break // every present symbol is already at the length cap
+		}
+		lengths[shortest]++
+	}
+
+	return lengths
+}
+
+func kraftSum(lengths [256]uint8, counts [256]uint32) float64 {
+	var sum float64
+	for symbol, c := range counts {
+		if c == 0 {
+			continue
+		}
+		sum += math.Pow(2, -float64(lengths[symbol]))
+	}
+	return sum
+}
+
+// canonicalCodes assigns canonical Huffman codewords for the given lengths:
+// symbols are ordered by (length, symbol value), and each codeword is the
+// previous one plus one, shifted left whenever length increases.
+func canonicalCodes(lengths [256]uint8) [256]huffCode {
+	type entry struct {
+		symbol int
+		length uint8
+	}
+
+	var present []entry
+	for symbol, l := range lengths {
+		if l > 0 {
+			present = append(present, entry{symbol, l})
+		}
+	}
+	sort.Slice(present, func(i, j int) bool {
+		if present[i].length != present[j].length {
+			return present[i].length < present[j].length
+		}
+		return present[i].symbol < present[j].symbol
+	})
+
+	var codes [256]huffCode
+	var code uint32
+	var prevLength uint8
+	for _, e := range present {
+		code <<= e.length - prevLength
+		codes[e.symbol] = huffCode{code: code, length: e.length}
+		code++
+		prevLength = e.length
+	}
+
+	return codes
+}
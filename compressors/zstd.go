@@ -0,0 +1,29 @@
+package compressors
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ZstdCodec compresses via klauspost/compress's pure-Go zstd implementation
+// at the default compression level.
+type ZstdCodec struct{}
+
+func (ZstdCodec) Name() string { return "zstd" }
+
+func (ZstdCodec) Compress(r io.Reader, w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	zw, err := zstd.NewWriter(cw)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := io.Copy(zw, r); err != nil {
+		zw.Close()
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+	return cw.n, nil
+}
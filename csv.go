@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// writeCSV renders the core fields of each report as a CSV table; the
+// per-window series isn't tabular and is omitted here (use -format json to
+// get it).
+func writeCSV(w io.Writer, reports []Report) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	header := []string{"filename", "size", "byte_entropy", "bit_entropy", "min_entropy", "delta", "ratio", "error"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range reports {
+		row := []string{
+			r.Filename,
+			fmt.Sprintf("%d", r.Size),
+			fmt.Sprintf("%.6f", r.ByteEntropy),
+			fmt.Sprintf("%.6f", r.BitEntropy),
+			fmt.Sprintf("%.6f", r.MinEntropy),
+			fmt.Sprintf("%.6f", r.Delta),
+			fmt.Sprintf("%.6f", r.Ratio),
+			r.Error,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return cw.Error()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bufio"
+	"os"
+)
+
+// bufSize is the buffered-reader chunk size used when mmap isn't available;
+// large enough that a directory of big files is bounded by disk throughput
+// rather than syscall count.
+const bufSize = 256 * 1024
+
+// fileHistogram builds a 256-entry byte histogram of f, preferring a
+// memory-mapped view of the file and falling back to a large buffered
+// reader when mmap isn't available (or the file is empty).
+func fileHistogram(f *os.File) (counts [256]uint32, total uint64, err error) {
+	info, err := f.Stat()
+	if err != nil {
+		return counts, 0, err
+	}
+
+	if info.Size() > 0 {
+		if data, ok := mmapFile(f, info.Size()); ok {
+			defer munmapFile(data)
+			for i := range data {
+				counts[data[i]]++
+			}
+			return counts, uint64(len(data)), nil
+		}
+	}
+
+	r := bufio.NewReaderSize(f, bufSize)
+	buf := make([]byte, bufSize)
+	for {
+		n, rerr := r.Read(buf)
+		for i := range buf[:n] {
+			counts[buf[i]]++
+		}
+		total += uint64(n)
+		if rerr != nil {
+			break
+		}
+	}
+
+	return counts, total, nil
+}
@@ -1,12 +1,21 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+
+	"github.com/Khaleelhabeeb/entropy-calculator/bitentropy"
+	"github.com/Khaleelhabeeb/entropy-calculator/compressors"
+	"github.com/Khaleelhabeeb/entropy-calculator/randtests"
 )
 
 func bitCount(i uint8) uint8 {
@@ -33,9 +42,34 @@ func calculateBitLevelEntropy(counts [256]uint32, total uint64) float64 {
 	return bitEntropySum
 }
 
+// options bundles every command-line flag needed to process a single file,
+// so worker goroutines don't need to touch the flag package directly.
+type options struct {
+	bitEntropy       bool
+	windowSize       int
+	windowStep       int
+	anomalyThreshold float64
+	symbolBits       int
+	ngram            int
+	lsbFirst         bool
+	compress         bool
+	runTests         bool
+}
+
 func main() {
 	bitEntropy := flag.Bool("b", false, "Calculate bit-level informational entropy")
 	flag.BoolVar(bitEntropy, "bit", false, "Calculate bit-level informational entropy")
+	windowSize := flag.Int("window", 0, "Compute entropy over sliding windows of this many bytes instead of a single global value")
+	windowStep := flag.Int("step", 0, "Step size in bytes between windows (defaults to -window, i.e. non-overlapping windows)")
+	anomalyThreshold := flag.Float64("anomaly", 0, "Flag windows whose entropy meets or exceeds this value (bits/byte) as anomalous, e.g. 7.5")
+	symbolBits := flag.Int("symbol-bits", 0, "Compute Shannon, min-, and collision entropy over N-bit symbols (1-16) read across byte boundaries")
+	ngram := flag.Int("ngram", 0, "Compute Shannon, min-, and collision entropy over K-byte n-grams (1-4)")
+	lsbFirst := flag.Bool("lsb", false, "With -symbol-bits, read bits least-significant-bit first instead of most-significant-bit first")
+	compress := flag.Bool("compress", false, "Run the input through gzip, zstd, and an order-0 Huffman coder and report achieved sizes alongside the entropy-derived lower bound")
+	runTests := flag.Bool("tests", false, "Run a battery of statistical randomness tests (chi-square, monobit, runs, serial) and report pass/fail with p-values")
+	workers := flag.Int("j", runtime.NumCPU(), "Number of files to process concurrently")
+	format := flag.String("format", "text", "Output format: text, json, csv, or pdf")
+	out := flag.String("o", "", "Output file for -format pdf (required); ignored otherwise")
 	flag.Parse()
 
 	if len(flag.Args()) == 0 {
@@ -43,66 +77,270 @@ func main() {
 		return
 	}
 
-	var output strings.Builder // Create a string builder to collect output
+	if *format == "pdf" && *out == "" {
+		fmt.Println("-format pdf requires -o <output.pdf>")
+		os.Exit(1)
+	}
+
+	opts := options{
+		bitEntropy:       *bitEntropy,
+		windowSize:       *windowSize,
+		windowStep:       *windowStep,
+		anomalyThreshold: *anomalyThreshold,
+		symbolBits:       *symbolBits,
+		ngram:            *ngram,
+		lsbFirst:         *lsbFirst,
+		compress:         *compress,
+		runTests:         *runTests,
+	}
+
+	reports := processFiles(flag.Args(), opts, *workers)
 
-	for _, filename := range flag.Args() {
-		f, err := os.Open(filename)
-		if err != nil {
-			output.WriteString(fmt.Sprintf("Error opening file %s: %v\n", filename, err))
-			continue
+	var err error
+	switch *format {
+	case "json":
+		err = writeJSON(os.Stdout, reports)
+	case "csv":
+		err = writeCSV(os.Stdout, reports)
+	case "pdf":
+		err = generateConsolidatedPDF(*out, reports, *anomalyThreshold)
+	default:
+		for _, r := range reports {
+			fmt.Print(r.Text)
 		}
-		defer f.Close()
-
-		var (
-			total uint64
-			counts [256]uint32
-		)
-
-		buf := make([]byte, 256)
-		for {
-			n, err := f.Read(buf)
-			if n == 0 || err != nil {
-				break
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s output: %v\n", *format, err)
+		os.Exit(1)
+	}
+}
+
+// processFiles runs processFile over filenames using a pool of `workers`
+// goroutines, and returns their reports in the original file order
+// regardless of which worker finished first.
+func processFiles(filenames []string, opts options, workers int) []Report {
+	if workers < 1 {
+		workers = 1
+	}
+
+	reports := make([]Report, len(filenames))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				reports[i] = processFile(filenames[i], opts)
 			}
+		}()
+	}
 
-			for i := 0; i < n; i += 8 {
-				for j := 0; j < 8 && i+j < n; j++ {
-					counts[buf[i+j]]++
-					total += 8
-				}
+	for i := range filenames {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return reports
+}
+
+// processFile runs every requested analysis over a single file and returns
+// its Report, including a preformatted Text block for -format text.
+func processFile(filename string, opts options) Report {
+	var output strings.Builder
+
+	f, err := os.Open(filename)
+	if err != nil {
+		output.WriteString(fmt.Sprintf("Error opening file %s: %v\n", filename, err))
+		return Report{Filename: filename, Error: err.Error(), Text: output.String()}
+	}
+	defer f.Close()
+
+	counts, total, err := fileHistogram(f)
+	if err != nil {
+		output.WriteString(fmt.Sprintf("Error reading file %s: %v\n", filename, err))
+		return Report{Filename: filename, Error: err.Error(), Text: output.String()}
+	}
+
+	var byteEntropy, maxP float64
+	for _, count := range counts {
+		if count > 0 {
+			p := float64(count) / float64(total)
+			byteEntropy -= p * math.Log2(p)
+			if p > maxP {
+				maxP = p
 			}
+		}
+	}
+	minEntropy := -math.Log2(maxP)
+	delta := float64(total) - (byteEntropy * float64(total) / 8)
+	ratio := 8 / byteEntropy
+
+	output.WriteString(fmt.Sprintf("--- File: %s ---\n", filepath.Base(filename)))
+	output.WriteString(fmt.Sprintf("Entropy per byte: %.6f bits or %.6f bytes\n", byteEntropy, byteEntropy/8))
+	output.WriteString(fmt.Sprintf("Entropy of file: %.6f bits or %.6f bytes\n", byteEntropy*float64(total), byteEntropy*float64(total)/8))
+	output.WriteString(fmt.Sprintf("Size of file: %d bytes\n", total))
+	output.WriteString(fmt.Sprintf("Delta: %.6f bytes compressible theoretically\n", delta))
+	output.WriteString(fmt.Sprintf("Best Theoretical Coding ratio: %.6f\n", ratio))
+	output.WriteString(fmt.Sprintf("Min-entropy: %.6f bits/byte\n", minEntropy))
 
-			for i := (n / 8) * 8; i < n; i++ {
-				counts[buf[i]]++
-				total++
+	if opts.compress {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			output.WriteString(fmt.Sprintf("Error seeking file %s for -compress: %v\n", filename, err))
+		} else if data, err := io.ReadAll(f); err != nil {
+			output.WriteString(fmt.Sprintf("Error reading file %s for -compress: %v\n", filename, err))
+		} else {
+			bound := byteEntropy * float64(total) / 8
+			output.WriteString(fmt.Sprintf("Entropy-derived lower bound: %.0f bytes\n", bound))
+			for _, codec := range compressors.All() {
+				n, err := codec.Compress(bytes.NewReader(data), io.Discard)
+				if err != nil {
+					output.WriteString(fmt.Sprintf("  %s: error: %v\n", codec.Name(), err))
+					continue
+				}
+				output.WriteString(fmt.Sprintf("  %s: %d bytes (ratio %.3f)\n", codec.Name(), n, float64(total)/float64(n)))
 			}
 		}
+	}
 
-		var byteEntropy float64
-		for _, count := range counts {
-			if count > 0 {
-				p := float64(count) / float64(total)
-				byteEntropy -= p * math.Log2(p)
+	if opts.runTests {
+		output.WriteString("Randomness tests:\n")
+		output.WriteString(formatTestResult(randtests.ChiSquareTest(counts, total)))
+
+		for _, run := range []struct {
+			name string
+			fn   func(io.Reader) (randtests.Result, error)
+		}{
+			{"monobit", randtests.MonobitTest},
+			{"runs", randtests.RunsTest},
+			{"serial-2", func(r io.Reader) (randtests.Result, error) { return randtests.SerialTest(r, 2) }},
+			{"serial-3", func(r io.Reader) (randtests.Result, error) { return randtests.SerialTest(r, 3) }},
+		} {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				output.WriteString(fmt.Sprintf("  %s: error seeking file: %v\n", run.name, err))
+				continue
+			}
+			result, err := run.fn(f)
+			if err != nil {
+				output.WriteString(fmt.Sprintf("  %s: error: %v\n", run.name, err))
+				continue
 			}
+			output.WriteString(formatTestResult(result))
 		}
+	}
+
+	var bitLevelEntropy float64
+	if opts.bitEntropy {
+		bitLevelEntropy = calculateBitLevelEntropy(counts, total)
+		output.WriteString(fmt.Sprintf("Informational entropy per bit: %.6f bits\n", bitLevelEntropy))
+		output.WriteString(fmt.Sprintf("Entropy per byte (bit-level): %.6f bits\n", bitLevelEntropy*8))
+		output.WriteString(fmt.Sprintf("Entropy of entire file (bit-level): %.6f bits\n", bitLevelEntropy*float64(total)))
+	}
 
-		output.WriteString(fmt.Sprintf("--- File: %s ---\n", filepath.Base(filename)))
-		output.WriteString(fmt.Sprintf("Entropy per byte: %.6f bits or %.6f bytes\n", byteEntropy, byteEntropy/8))
-		output.WriteString(fmt.Sprintf("Entropy of file: %.6f bits or %.6f bytes\n", byteEntropy*float64(total), byteEntropy*float64(total)/8))
-		output.WriteString(fmt.Sprintf("Size of file: %d bytes\n", total))
-		output.WriteString(fmt.Sprintf("Delta: %.6f bytes compressible theoretically\n", float64(total)-(byteEntropy*float64(total)/8)))
-		output.WriteString(fmt.Sprintf("Best Theoretical Coding ratio: %.6f\n", 8/byteEntropy))
-
-		if *bitEntropy {
-			bitLevelEntropy := calculateBitLevelEntropy(counts, total)
-			output.WriteString(fmt.Sprintf("Informational entropy per bit: %.6f bits\n", bitLevelEntropy))
-			output.WriteString(fmt.Sprintf("Entropy per byte (bit-level): %.6f bits\n", bitLevelEntropy*8))
-			output.WriteString(fmt.Sprintf("Entropy of entire file (bit-level): %.6f bits\n", bitLevelEntropy*float64(total)))
+	if opts.symbolBits > 0 {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			output.WriteString(fmt.Sprintf("Error seeking file %s for symbol entropy: %v\n", filename, err))
+		} else if stats, err := bitentropy.SymbolEntropy(f, opts.symbolBits, !opts.lsbFirst); err != nil {
+			output.WriteString(fmt.Sprintf("Error computing symbol entropy for %s: %v\n", filename, err))
+		} else {
+			output.WriteString(fmt.Sprintf("Symbol entropy (%d-bit symbols): Shannon %.6f, min-entropy %.6f, collision entropy %.6f bits/symbol\n", opts.symbolBits, stats.Shannon, stats.Min, stats.Collision))
 		}
+	}
 
-		output.WriteString("\n")
+	if opts.ngram > 0 {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			output.WriteString(fmt.Sprintf("Error seeking file %s for n-gram entropy: %v\n", filename, err))
+		} else if stats, err := bitentropy.NGramEntropy(f, opts.ngram); err != nil {
+			output.WriteString(fmt.Sprintf("Error computing n-gram entropy for %s: %v\n", filename, err))
+		} else {
+			output.WriteString(fmt.Sprintf("%d-byte n-gram entropy: Shannon %.6f, min-entropy %.6f, collision entropy %.6f bits/symbol\n", opts.ngram, stats.Shannon, stats.Min, stats.Collision))
+		}
 	}
 
-	// Print the output content
-	fmt.Print(output.String())
+	var windows []windowResult
+	if opts.windowSize > 0 {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			output.WriteString(fmt.Sprintf("Error seeking file %s for windowed scan: %v\n", filename, err))
+		} else if results, err := slidingWindowEntropy(f, opts.windowSize, opts.windowStep, opts.anomalyThreshold); err != nil {
+			output.WriteString(fmt.Sprintf("Error computing windowed entropy for %s: %v\n", filename, err))
+		} else {
+			windows = results
+			output.WriteString(fmt.Sprintf("Windowed entropy (window=%d, step=%d):\n", opts.windowSize, stepOrDefault(opts.windowStep, opts.windowSize)))
+			for _, w := range results {
+				marker := ""
+				if w.Anomaly {
+					marker = "  <-- anomaly"
+				}
+				output.WriteString(fmt.Sprintf("  offset %10d: entropy %.6f bits/byte, chi-square %.2f%s\n", w.Offset, w.Entropy, w.ChiSquare, marker))
+			}
+			output.WriteString(fmt.Sprintf("  sparkline: %s\n", sparkline(results)))
+		}
+	}
+
+	output.WriteString("\n")
+
+	return Report{
+		Filename:    filename,
+		Size:        total,
+		ByteEntropy: byteEntropy,
+		BitEntropy:  bitLevelEntropy,
+		MinEntropy:  minEntropy,
+		Delta:       delta,
+		Ratio:       ratio,
+		Windows:     windows,
+		Histogram:   counts,
+		Text:        output.String(),
+	}
+}
+
+// formatTestResult renders a single randtests.Result as a pass/fail line
+// with its statistic and p-value.
+func formatTestResult(r randtests.Result) string {
+	verdict := "FAIL"
+	if r.Pass {
+		verdict = "PASS"
+	}
+	return fmt.Sprintf("  %-10s %s  statistic=%.6f  p-value=%.6f\n", r.Name, verdict, r.Statistic, r.PValue)
+}
+
+// stepOrDefault reports the effective step size used by slidingWindowEntropy,
+// which treats a non-positive step as equal to the window size.
+func stepOrDefault(step, window int) int {
+	if step <= 0 {
+		return window
+	}
+	return step
+}
+
+// writeJSON renders reports as a single JSON array. Degenerate inputs (an
+// empty file, or one made of a single repeated byte) drive ByteEntropy to
+// zero, which makes Ratio/MinEntropy mathematically infinite; encoding/json
+// rejects Inf and NaN, so those fields are sanitized to 0 here rather than
+// in the Report itself, leaving the text and CSV renderers free to print
+// the literal +Inf.
+func writeJSON(w io.Writer, reports []Report) error {
+	safe := make([]Report, len(reports))
+	for i, r := range reports {
+		r.ByteEntropy = sanitizeFloat(r.ByteEntropy)
+		r.BitEntropy = sanitizeFloat(r.BitEntropy)
+		r.MinEntropy = sanitizeFloat(r.MinEntropy)
+		r.Delta = sanitizeFloat(r.Delta)
+		r.Ratio = sanitizeFloat(r.Ratio)
+		safe[i] = r
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(safe)
+}
+
+// sanitizeFloat replaces non-finite values (+/-Inf, NaN) with 0, since
+// encoding/json cannot represent them.
+func sanitizeFloat(f float64) float64 {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0
+	}
+	return f
 }
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// mmapFile is unsupported on this platform; the caller falls back to a
+// regular buffered read.
+func mmapFile(f *os.File, size int64) ([]byte, bool) {
+	return nil, false
+}
+
+// munmapFile is unused on this platform since mmapFile never succeeds.
+func munmapFile(data []byte) {}
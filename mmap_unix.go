@@ -0,0 +1,24 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile memory-maps f for reading. The bool result is false if mmap
+// isn't usable for this file, in which case the caller should fall back to
+// a regular buffered read.
+func mmapFile(f *os.File, size int64) ([]byte, bool) {
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// munmapFile releases a mapping returned by mmapFile.
+func munmapFile(data []byte) {
+	_ = syscall.Munmap(data)
+}
@@ -2,38 +2,147 @@ package main
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/jung-kurt/gofpdf"
 )
 
-func generatePDF(filename string, content string) error {
-	pdf := gofpdf.New("P", "mm", "A4", "") // Create a new PDF instance
-	pdf.AddPage()                           // Add a new page to the PDF
+// generateConsolidatedPDF writes a single PDF covering every report: a
+// summary page with a table of all files, followed by a per-file histogram
+// bar chart and, for files scanned with -window, a windowed entropy line
+// chart.
+func generateConsolidatedPDF(filename string, reports []Report, anomalyThreshold float64) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
 
-	pdf.SetFont("Arial", "B", 16) // Set font and size
-	pdf.Cell(40, 10, "Entropy Analysis Results") // Write a title to the PDF
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(40, 10, "Entropy Analysis Report")
+	pdf.Ln(14)
+	drawSummaryTable(pdf, reports)
 
-	pdf.SetFont("Arial", "", 12) // Set font and size
-	pdf.MultiCell(0, 10, content, "", "", false) // Write the content to the PDF
+	for _, r := range reports {
+		if r.Error != "" {
+			continue
+		}
 
-	return pdf.OutputFileAndClose(filename) // Save the PDF to the specified filename
+		pdf.AddPage()
+		pdf.SetFont("Arial", "B", 14)
+		pdf.Cell(40, 10, r.Filename)
+		pdf.Ln(14)
+		drawHistogramChart(pdf, r.Histogram)
+
+		if len(r.Windows) > 0 {
+			pdf.AddPage()
+			pdf.SetFont("Arial", "B", 14)
+			pdf.Cell(40, 10, fmt.Sprintf("%s - Windowed Entropy", r.Filename))
+			pdf.Ln(14)
+			drawEntropyChart(pdf, r.Windows, anomalyThreshold)
+		}
+	}
+
+	return pdf.OutputFileAndClose(filename)
 }
 
-func generate() {
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: output <output_filename> <output_content>")
+// drawSummaryTable renders one row per report with its headline entropy
+// figures, using gofpdf's Cell primitive to lay out a simple grid.
+func drawSummaryTable(pdf *gofpdf.Fpdf, reports []Report) {
+	const rowHeight = 8.0
+	widths := []float64{70, 25, 30, 30, 30}
+
+	pdf.SetFont("Arial", "B", 10)
+	for i, h := range []string{"File", "Size", "Entropy/byte", "Min-entropy", "Ratio"} {
+		pdf.CellFormat(widths[i], rowHeight, h, "1", 0, "L", false, 0, "")
+	}
+	pdf.Ln(rowHeight)
+
+	pdf.SetFont("Arial", "", 10)
+	for _, r := range reports {
+		if r.Error != "" {
+			pdf.CellFormat(widths[0], rowHeight, r.Filename, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(widths[1]+widths[2]+widths[3]+widths[4], rowHeight, "error: "+r.Error, "1", 0, "L", false, 0, "")
+			pdf.Ln(rowHeight)
+			continue
+		}
+
+		pdf.CellFormat(widths[0], rowHeight, r.Filename, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], rowHeight, fmt.Sprintf("%d", r.Size), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[2], rowHeight, fmt.Sprintf("%.4f", r.ByteEntropy), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], rowHeight, fmt.Sprintf("%.4f", r.MinEntropy), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], rowHeight, fmt.Sprintf("%.4f", r.Ratio), "1", 0, "R", false, 0, "")
+		pdf.Ln(rowHeight)
+	}
+}
+
+// drawHistogramChart renders a 256-bucket byte histogram as a bar chart
+// using gofpdf's Rect primitive, one thin bar per byte value.
+func drawHistogramChart(pdf *gofpdf.Fpdf, counts [256]uint32) {
+	const (
+		chartX, chartY          = 15.0, 20.0
+		chartWidth, chartHeight = 180.0, 80.0
+	)
+
+	var max uint32
+	for _, c := range counts {
+		if c > max {
+			max = c
+		}
+	}
+	if max == 0 {
 		return
 	}
 
-	outputFilename := os.Args[1]
-	outputContent := os.Args[2]
+	barWidth := chartWidth / 256
+	pdf.SetFillColor(60, 60, 200)
+	for i, c := range counts {
+		h := chartHeight * float64(c) / float64(max)
+		x := chartX + float64(i)*barWidth
+		y := chartY + (chartHeight - h)
+		pdf.Rect(x, y, barWidth, h, "F")
+	}
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Rect(chartX, chartY, chartWidth, chartHeight, "D")
+}
 
-	// Generate PDF file with output content
-	if err := generatePDF(outputFilename, outputContent); err != nil {
-		fmt.Printf("Error generating PDF: %v\n", err)
+// drawEntropyChart renders a per-window entropy series as a line chart
+// using gofpdf's line-drawing primitives, plotting offset on the x-axis
+// and entropy (0-8 bits/byte) on the y-axis, with a dashed-style threshold
+// line when anomalyThreshold is set.
+func drawEntropyChart(pdf *gofpdf.Fpdf, results []windowResult, anomalyThreshold float64) {
+	if len(results) == 0 {
 		return
 	}
 
-	fmt.Printf("Output saved to %s\n", outputFilename)
+	const (
+		chartX, chartY          = 15.0, 20.0
+		chartWidth, chartHeight = 180.0, 80.0
+		maxEntropy              = 8.0
+	)
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Line(chartX, chartY, chartX, chartY+chartHeight)
+	pdf.Line(chartX, chartY+chartHeight, chartX+chartWidth, chartY+chartHeight)
+
+	if anomalyThreshold > 0 {
+		ty := chartY + chartHeight*(1-anomalyThreshold/maxEntropy)
+		pdf.SetDrawColor(200, 0, 0)
+		pdf.Line(chartX, ty, chartX+chartWidth, ty)
+		pdf.SetDrawColor(0, 0, 0)
+	}
+
+	steps := len(results) - 1
+	if steps < 1 {
+		steps = 1
+	}
+	xStep := chartWidth / float64(steps)
+
+	prevX := chartX
+	prevY := chartY + chartHeight*(1-results[0].Entropy/maxEntropy)
+	for i, r := range results {
+		x := chartX + float64(i)*xStep
+		y := chartY + chartHeight*(1-r.Entropy/maxEntropy)
+		if i > 0 {
+			pdf.Line(prevX, prevY, x, y)
+		}
+		prevX, prevY = x, y
+	}
 }
@@ -0,0 +1,21 @@
+package randtests
+
+// ChiSquareTest runs a chi-square goodness-of-fit test against a uniform
+// byte distribution (df=255) over a byte histogram, such as the counts[256]
+// array the caller already built while computing byte entropy.
+func ChiSquareTest(counts [256]uint32, total uint64) Result {
+	if total == 0 {
+		return Result{Name: "chi-square"}
+	}
+
+	expected := float64(total) / 256
+	var chiSquare float64
+	for _, c := range counts {
+		diff := float64(c) - expected
+		chiSquare += diff * diff / expected
+	}
+
+	const df = 255
+	p := igamc(df/2, chiSquare/2)
+	return Result{Name: "chi-square", Statistic: chiSquare, PValue: p, Pass: p >= defaultAlpha}
+}
@@ -0,0 +1,35 @@
+package randtests
+
+import (
+	"bufio"
+	"io"
+	"math"
+
+	"github.com/Khaleelhabeeb/entropy-calculator/bitentropy"
+)
+
+// MonobitTest runs the NIST-style frequency (monobit) test: it checks that
+// the proportion of one-bits in the stream is consistent with a fair coin,
+// i.e. z = |ones - zeros| / sqrt(n) and p = erfc(z / sqrt(2)).
+func MonobitTest(r io.Reader) (Result, error) {
+	bs := bitentropy.NewBitStream(bufio.NewReader(r), true)
+
+	var ones, total int64
+	for {
+		bit, err := bs.Next(1)
+		if err != nil {
+			break
+		}
+		ones += int64(bit)
+		total++
+	}
+	if total == 0 {
+		return Result{Name: "monobit"}, nil
+	}
+
+	zeros := total - ones
+	z := math.Abs(float64(ones-zeros)) / math.Sqrt(float64(total))
+	p := math.Erfc(z / math.Sqrt2)
+
+	return Result{Name: "monobit", Statistic: z, PValue: p, Pass: p >= defaultAlpha}, nil
+}
@@ -0,0 +1,17 @@
+// Package randtests implements a small battery of standard statistical
+// randomness tests (chi-square, monobit, runs, serial), each exposed as an
+// independently usable function so high-entropy files can be checked for
+// actual randomness rather than mere uniformity.
+package randtests
+
+// Result holds the outcome of a single statistical randomness test.
+type Result struct {
+	Name      string
+	Statistic float64
+	PValue    float64
+	Pass      bool
+}
+
+// defaultAlpha is the significance level below which a test is considered
+// failed, matching the threshold used throughout the NIST SP 800-22 suite.
+const defaultAlpha = 0.01
@@ -0,0 +1,50 @@
+package randtests
+
+import (
+	"bufio"
+	"io"
+	"math"
+
+	"github.com/Khaleelhabeeb/entropy-calculator/bitentropy"
+)
+
+// RunsTest runs the NIST-style runs test: it checks that the number of runs
+// (maximal sequences of identical bits) is consistent with the observed
+// proportion of ones, catching streams that oscillate or clump more than
+// chance would even though the overall bit balance looks fine.
+func RunsTest(r io.Reader) (Result, error) {
+	bs := bitentropy.NewBitStream(bufio.NewReader(r), true)
+
+	var n, ones, runs int64
+	var prev byte
+	havePrev := false
+	for {
+		bit, err := bs.Next(1)
+		if err != nil {
+			break
+		}
+		ones += int64(bit)
+		if !havePrev {
+			runs = 1
+			havePrev = true
+		} else if byte(bit) != prev {
+			runs++
+		}
+		prev = byte(bit)
+		n++
+	}
+	if n == 0 {
+		return Result{Name: "runs"}, nil
+	}
+
+	pi := float64(ones) / float64(n)
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(float64(n)) {
+		// Frequency test prerequisite fails: the run count isn't meaningful.
+		return Result{Name: "runs", Statistic: float64(runs)}, nil
+	}
+
+	vObs := float64(runs)
+	p := math.Erfc(math.Abs(vObs-2*float64(n)*pi*(1-pi)) / (2 * math.Sqrt(2*float64(n)) * pi * (1 - pi)))
+
+	return Result{Name: "runs", Statistic: vObs, PValue: p, Pass: p >= defaultAlpha}, nil
+}
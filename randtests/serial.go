@@ -0,0 +1,63 @@
+package randtests
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/Khaleelhabeeb/entropy-calculator/bitentropy"
+)
+
+// SerialTest runs a serial test over non-overlapping width-bit patterns
+// (width=2 or 3 is typical). It compares the observed Shannon entropy of
+// the pattern distribution against the width-bit maximum via a G-test
+// statistic (2 * n * ln(2) * entropy deficit), which is asymptotically
+// chi-square distributed with 2^width-1 degrees of freedom.
+func SerialTest(r io.Reader, width int) (Result, error) {
+	name := fmt.Sprintf("serial-%d", width)
+
+	shannon, n, err := patternEntropy(r, width)
+	if err != nil {
+		return Result{}, err
+	}
+	if n == 0 {
+		return Result{Name: name}, nil
+	}
+
+	deficit := float64(width) - shannon
+	g := 2 * float64(n) * math.Ln2 * deficit
+	df := float64(uint64(1)<<uint(width) - 1)
+	p := igamc(df/2, g/2)
+
+	return Result{Name: name, Statistic: g, PValue: p, Pass: p >= defaultAlpha}, nil
+}
+
+// patternEntropy returns the Shannon entropy (bits/pattern) of the
+// distribution of non-overlapping width-bit patterns in r, along with how
+// many patterns were observed.
+func patternEntropy(r io.Reader, width int) (float64, uint64, error) {
+	bs := bitentropy.NewBitStream(bufio.NewReader(r), true)
+
+	counts := make(map[uint32]uint64)
+	var total uint64
+	for {
+		symbol, err := bs.Next(width)
+		if err != nil {
+			break
+		}
+		counts[symbol]++
+		total++
+	}
+	if total == 0 {
+		return 0, 0, nil
+	}
+
+	var shannon float64
+	for _, c := range counts {
+		p := float64(c) / float64(total)
+		shannon -= p * math.Log2(p)
+	}
+
+	return shannon, total, nil
+}
@@ -0,0 +1,20 @@
+package main
+
+// Report holds the entropy analysis results for a single file, independent
+// of how they'll be rendered (text, JSON, CSV, or PDF).
+type Report struct {
+	Filename    string         `json:"filename"`
+	Size        uint64         `json:"size"`
+	ByteEntropy float64        `json:"byte_entropy"`
+	BitEntropy  float64        `json:"bit_entropy,omitempty"`
+	MinEntropy  float64        `json:"min_entropy"`
+	Delta       float64        `json:"delta"`
+	Ratio       float64        `json:"ratio"`
+	Windows     []windowResult `json:"windows,omitempty"`
+	Error       string         `json:"error,omitempty"`
+
+	// Histogram and Text carry data needed by the text and PDF renderers
+	// but aren't part of the structured JSON/CSV output.
+	Histogram [256]uint32 `json:"-"`
+	Text      string      `json:"-"`
+}
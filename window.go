@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// windowResult holds the entropy metrics computed for a single window of a
+// sliding-window scan.
+type windowResult struct {
+	Offset    uint64  `json:"offset"`
+	Entropy   float64 `json:"entropy"`
+	ChiSquare float64 `json:"chi_square"`
+	Anomaly   bool    `json:"anomaly"`
+}
+
+// windowStats computes the Shannon entropy (bits/byte) and the chi-square
+// statistic against a uniform distribution for a histogram covering n bytes.
+func windowStats(counts [256]uint32, n int) (entropy, chiSquare float64) {
+	if n == 0 {
+		return 0, 0
+	}
+
+	total := float64(n)
+	expected := total / 256
+	for _, c := range counts {
+		if c > 0 {
+			p := float64(c) / total
+			entropy -= p * math.Log2(p)
+		}
+		diff := float64(c) - expected
+		chiSquare += diff * diff / expected
+	}
+
+	return entropy, chiSquare
+}
+
+// slidingWindowEntropy streams r through a fixed-size ring buffer of `window`
+// bytes, advancing by `step` bytes between samples, and returns one
+// windowResult per window encountered. Memory use is bounded by window size
+// regardless of input length: overlapping windows (step < window) update the
+// histogram incrementally rather than rescanning the whole window, and
+// non-overlapping windows (step >= window) reset and refill it. A final
+// partial window at EOF is reported using whatever bytes were read.
+func slidingWindowEntropy(r io.Reader, window, step int, anomalyThreshold float64) ([]windowResult, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window size must be positive")
+	}
+	if step <= 0 {
+		step = window
+	}
+
+	var (
+		results []windowResult
+		offset  uint64
+		counts  [256]uint32
+		ring    = make([]byte, window)
+	)
+
+	filled, err := io.ReadFull(r, ring)
+	for _, b := range ring[:filled] {
+		counts[b]++
+	}
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	for filled > 0 {
+		entropy, chiSquare := windowStats(counts, filled)
+		results = append(results, windowResult{
+			Offset:    offset,
+			Entropy:   entropy,
+			ChiSquare: chiSquare,
+			Anomaly:   anomalyThreshold > 0 && entropy >= anomalyThreshold,
+		})
+
+		if filled < window {
+			break
+		}
+		offset += uint64(step)
+
+		if step >= window {
+			if gap := step - window; gap > 0 {
+				if _, err := io.CopyN(io.Discard, r, int64(gap)); err != nil && err != io.EOF {
+					return nil, err
+				}
+			}
+			counts = [256]uint32{}
+			filled, err = io.ReadFull(r, ring)
+			for _, b := range ring[:filled] {
+				counts[b]++
+			}
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return nil, err
+			}
+			continue
+		}
+
+		// Overlapping window: slide by step, updating the histogram
+		// incrementally instead of rescanning the whole window.
+		for _, b := range ring[:step] {
+			counts[b]--
+		}
+		copy(ring, ring[step:])
+		n, rerr := io.ReadFull(r, ring[window-step:])
+		for _, b := range ring[window-step : window-step+n] {
+			counts[b]++
+		}
+		filled = filled - step + n
+		if rerr != nil && rerr != io.EOF && rerr != io.ErrUnexpectedEOF {
+			return nil, rerr
+		}
+	}
+
+	return results, nil
+}
+
+// sparkBlocks are the block characters used to render an ASCII sparkline,
+// from lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders a per-window entropy series as a single line of Unicode
+// block characters, scaled against the 8 bits/byte maximum.
+func sparkline(results []windowResult) string {
+	if len(results) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, r := range results {
+		level := int(r.Entropy / 8 * float64(len(sparkBlocks)-1))
+		if level < 0 {
+			level = 0
+		} else if level >= len(sparkBlocks) {
+			level = len(sparkBlocks) - 1
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+
+	return b.String()
+}